@@ -0,0 +1,53 @@
+// Package auth defines the provider-agnostic domain types this app's login
+// flow is built around. Concrete identity providers (rso, mockidp, ...)
+// implement IdentityProvider; the usecase and delivery layers depend only
+// on this package, never on a specific provider.
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+// Token is the normalized result of an IdentityProvider's code exchange or
+// refresh.
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	IDToken      string
+	ExpiresAt    time.Time
+}
+
+// Profile is the normalized result of an IdentityProvider's UserInfo call.
+type Profile struct {
+	Subject  string // stable per-user identifier; the puuid for rso
+	GameName string
+	TagLine  string
+}
+
+// PKCE carries the per-authorization-request secrets an IdentityProvider
+// needs to build an authorize URL (Challenge, Method, Nonce) and later
+// verify a code exchange (Verifier).
+type PKCE struct {
+	Verifier  string
+	Challenge string
+	Method    string // "S256" or "plain"
+	Nonce     string
+}
+
+// IdentityProvider is implemented by each supported identity provider so
+// the usecase and delivery layers never depend on a specific provider's
+// token or profile API shape. rso is the production implementation; mockidp
+// lets tests and local development run without real credentials.
+type IdentityProvider interface {
+	// AuthorizeURL returns the URL to send the user to, embedding state
+	// and the PKCE challenge.
+	AuthorizeURL(state string, pkce PKCE) string
+	// Exchange trades an authorization code, plus the PKCE that produced
+	// its challenge, for a Token.
+	Exchange(ctx context.Context, code string, pkce PKCE) (*Token, error)
+	// Refresh trades a refresh token for a new Token.
+	Refresh(ctx context.Context, refreshToken string) (*Token, error)
+	// UserInfo returns the profile associated with token.
+	UserInfo(ctx context.Context, token *Token) (*Profile, error)
+}
@@ -0,0 +1,141 @@
+// Package http adapts the auth usecase to net/http handlers: cookie
+// handling, query parsing, and page rendering live here so the usecase and
+// provider layers stay free of HTTP concerns.
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/RiotGames/developer-relations/sample_apps/rso_sample_apps/go/internal/auth/repository/pkce"
+	"github.com/RiotGames/developer-relations/sample_apps/rso_sample_apps/go/internal/auth/repository/session"
+	"github.com/RiotGames/developer-relations/sample_apps/rso_sample_apps/go/internal/auth/usecase"
+	"github.com/RiotGames/developer-relations/sample_apps/rso_sample_apps/go/riotapi"
+	"github.com/RiotGames/developer-relations/sample_apps/rso_sample_apps/go/web"
+)
+
+const (
+	pkceCookieName    = "pkce_session"
+	sessionCookieName = "rso_session"
+)
+
+// accountView is the account data rendered by profile.gohtml, taken from
+// the session's Profile (fetched once, at login) rather than re-queried
+// from account-v1 on every page view. The html tags drive
+// web.renderStruct, mirroring riotapi.Account's.
+type accountView struct {
+	Puuid    string `html:"l=Puuid,e=span,c=puuid"`
+	GameName string `html:"l=GameName,e=span,c=gamename"`
+	TagLine  string `html:"l=TagLine,e=span,c=tagline"`
+}
+
+// Handler wires the auth usecase to HTTP, plus the riotapi client and
+// renderer needed to build the post-login profile page.
+type Handler struct {
+	UseCase  *usecase.UseCase
+	Sessions session.Store
+	RiotAPI  *riotapi.Client
+	Renderer *web.Renderer
+}
+
+// New returns a Handler.
+func New(uc *usecase.UseCase, sessions session.Store, riot *riotapi.Client, renderer *web.Renderer) *Handler {
+	return &Handler{UseCase: uc, Sessions: sessions, RiotAPI: riot, Renderer: renderer}
+}
+
+// Login starts a new authorization request and renders a sign-in link.
+func (h *Handler) Login(w http.ResponseWriter, req *http.Request) {
+	pkceSessionID, authorizeURL, err := h.UseCase.BeginLogin()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to start login: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     pkceCookieName,
+		Value:    pkceSessionID,
+		Path:     "/",
+		MaxAge:   int(pkce.TTL.Seconds()),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := h.Renderer.Render(w, "login.gohtml", struct{ SignInURL string }{SignInURL: authorizeURL}); err != nil {
+		http.Error(w, "failed to render page", http.StatusInternalServerError)
+	}
+}
+
+// Callback completes the authorization request started by Login.
+func (h *Handler) Callback(w http.ResponseWriter, req *http.Request) {
+	cookie, err := req.Cookie(pkceCookieName)
+	if err != nil {
+		http.Error(w, "missing pkce session cookie", http.StatusBadRequest)
+		return
+	}
+
+	code := req.URL.Query().Get("code")
+	state := req.URL.Query().Get("state")
+
+	sessionID, err := h.UseCase.CompleteLogin(req.Context(), cookie.Value, code, state)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("login failed: %v", err), http.StatusUnauthorized)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    sessionID,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := h.Renderer.Render(w, "callback.gohtml", struct{ ShowDataURL string }{ShowDataURL: "/show-data/"}); err != nil {
+		http.Error(w, "failed to render page", http.StatusInternalServerError)
+	}
+}
+
+// ShowData resolves the caller's session and renders their account and
+// champion rotation data. Account data comes from the Profile the provider
+// returned at login (stored on the session), not a fresh account-v1 call,
+// so this works under every auth.IdentityProvider, including mockidp.
+// Champion rotations still come from the Riot API using the app's own
+// RGAPI_TOKEN, which is required regardless of AUTH_PROVIDER.
+func (h *Handler) ShowData(w http.ResponseWriter, req *http.Request) {
+	cookie, err := req.Cookie(sessionCookieName)
+	if err != nil {
+		http.Redirect(w, req, "/", http.StatusFound)
+		return
+	}
+
+	sess, ok, err := h.Sessions.Get(cookie.Value)
+	if err != nil {
+		http.Error(w, "failed to load session", http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		http.Redirect(w, req, "/", http.StatusFound)
+		return
+	}
+
+	rotation, err := h.RiotAPI.ChampionRotations(req.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("fetching champion rotation data: %v", err), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	data := struct {
+		Account          accountView
+		ChampionRotation *riotapi.ChampionRotation
+	}{
+		Account:          accountView{Puuid: sess.Puuid, GameName: sess.GameName, TagLine: sess.TagLine},
+		ChampionRotation: rotation,
+	}
+	if err := h.Renderer.Render(w, "profile.gohtml", data); err != nil {
+		http.Error(w, "failed to render page", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,110 @@
+// Package mockidp is an auth.IdentityProvider for local development and
+// tests: it never calls out to a real identity provider, instead returning
+// canned token and profile data read from fixture files on disk, so login
+// and the account data on /show-data/ work without real RSO credentials.
+// Its sign-in link is a mockidp:// placeholder that a browser cannot
+// follow; a login completes when the app's callback path is requested
+// with the state from that link (any code is accepted).
+// /show-data/'s champion rotation data still comes from the real Riot API
+// and needs a real RGAPI_TOKEN regardless of which provider is configured.
+package mockidp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/RiotGames/developer-relations/sample_apps/rso_sample_apps/go/internal/auth"
+)
+
+// DefaultFixturesDir is used when no directory is configured.
+const DefaultFixturesDir = "internal/auth/mockidp/fixtures"
+
+// Provider is an auth.IdentityProvider backed by JSON fixture files instead
+// of a real identity provider.
+type Provider struct {
+	// FixturesDir holds token.json and userinfo.json.
+	FixturesDir string
+}
+
+// New returns a Provider reading fixtures from fixturesDir, or
+// DefaultFixturesDir if it's empty.
+func New(fixturesDir string) *Provider {
+	if fixturesDir == "" {
+		fixturesDir = DefaultFixturesDir
+	}
+	return &Provider{FixturesDir: fixturesDir}
+}
+
+// AuthorizeURL returns a placeholder URL carrying state and the PKCE
+// challenge, useful for dev/test assertions; mockidp has no real authorize
+// endpoint for a browser to hit.
+func (p *Provider) AuthorizeURL(state string, pk auth.PKCE) string {
+	return fmt.Sprintf("mockidp://authorize?state=%s&code_challenge=%s&code_challenge_method=%s&nonce=%s",
+		state, pk.Challenge, pk.Method, pk.Nonce)
+}
+
+type tokenFixture struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	IDToken      string `json:"id_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// Exchange ignores code and pk and returns the contents of token.json.
+func (p *Provider) Exchange(ctx context.Context, code string, pk auth.PKCE) (*auth.Token, error) {
+	var fixture tokenFixture
+	if err := p.readFixture("token.json", &fixture); err != nil {
+		return nil, fmt.Errorf("mockidp: exchange: %w", err)
+	}
+	return tokenFromFixture(fixture), nil
+}
+
+// Refresh ignores refreshToken and returns the contents of token.json.
+func (p *Provider) Refresh(ctx context.Context, refreshToken string) (*auth.Token, error) {
+	var fixture tokenFixture
+	if err := p.readFixture("token.json", &fixture); err != nil {
+		return nil, fmt.Errorf("mockidp: refresh: %w", err)
+	}
+	return tokenFromFixture(fixture), nil
+}
+
+type userInfoFixture struct {
+	Puuid    string `json:"puuid"`
+	GameName string `json:"gameName"`
+	TagLine  string `json:"tagLine"`
+}
+
+// UserInfo ignores token and returns the contents of userinfo.json.
+func (p *Provider) UserInfo(ctx context.Context, token *auth.Token) (*auth.Profile, error) {
+	var fixture userInfoFixture
+	if err := p.readFixture("userinfo.json", &fixture); err != nil {
+		return nil, fmt.Errorf("mockidp: user info: %w", err)
+	}
+
+	return &auth.Profile{
+		Subject:  fixture.Puuid,
+		GameName: fixture.GameName,
+		TagLine:  fixture.TagLine,
+	}, nil
+}
+
+func (p *Provider) readFixture(name string, v any) error {
+	data, err := os.ReadFile(filepath.Join(p.FixturesDir, name))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func tokenFromFixture(f tokenFixture) *auth.Token {
+	return &auth.Token{
+		AccessToken:  f.AccessToken,
+		RefreshToken: f.RefreshToken,
+		IDToken:      f.IDToken,
+		ExpiresAt:    time.Now().Add(time.Duration(f.ExpiresIn) * time.Second),
+	}
+}
@@ -0,0 +1,58 @@
+package mockidp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RiotGames/developer-relations/sample_apps/rso_sample_apps/go/internal/auth"
+)
+
+func TestProviderExchange(t *testing.T) {
+	provider := New("fixtures")
+
+	token, err := provider.Exchange(context.Background(), "any-code", auth.PKCE{Verifier: "any-verifier"})
+	if err != nil {
+		t.Fatalf("Exchange() error = %v, want nil", err)
+	}
+	if token.AccessToken != "mock-access-token" {
+		t.Errorf("AccessToken = %q, want %q", token.AccessToken, "mock-access-token")
+	}
+	if token.ExpiresAt.IsZero() {
+		t.Error("ExpiresAt is zero, want non-zero")
+	}
+}
+
+func TestProviderRefresh(t *testing.T) {
+	provider := New("fixtures")
+
+	token, err := provider.Refresh(context.Background(), "any-refresh-token")
+	if err != nil {
+		t.Fatalf("Refresh() error = %v, want nil", err)
+	}
+	if token.RefreshToken != "mock-refresh-token" {
+		t.Errorf("RefreshToken = %q, want %q", token.RefreshToken, "mock-refresh-token")
+	}
+}
+
+func TestProviderUserInfo(t *testing.T) {
+	provider := New("fixtures")
+
+	profile, err := provider.UserInfo(context.Background(), &auth.Token{AccessToken: "mock-access-token"})
+	if err != nil {
+		t.Fatalf("UserInfo() error = %v, want nil", err)
+	}
+	if profile.Subject != "mock-puuid-0000" {
+		t.Errorf("Subject = %q, want %q", profile.Subject, "mock-puuid-0000")
+	}
+	if profile.GameName != "MockSummoner" {
+		t.Errorf("GameName = %q, want %q", profile.GameName, "MockSummoner")
+	}
+}
+
+func TestProviderExchangeMissingFixtures(t *testing.T) {
+	provider := New("fixtures-that-do-not-exist")
+
+	if _, err := provider.Exchange(context.Background(), "any-code", auth.PKCE{}); err == nil {
+		t.Error("Exchange() error = nil, want error for missing fixtures dir")
+	}
+}
@@ -0,0 +1,91 @@
+// Package pkce persists the PKCE verifier, CSRF state, and OIDC nonce
+// generated for an in-flight authorization request, between the login
+// handler that creates them and the callback handler that consumes them.
+package pkce
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"sync"
+	"time"
+
+	"github.com/RiotGames/developer-relations/sample_apps/rso_sample_apps/go/internal/auth"
+)
+
+// TTL is how long an Entry is valid for. Entries older than this are
+// treated as absent even if still present in the store.
+const TTL = 10 * time.Minute
+
+// Entry is what's stored between a login request and its matching
+// callback.
+type Entry struct {
+	PKCE      auth.PKCE
+	State     string
+	CreatedAt time.Time
+}
+
+// Store persists Entries behind an opaque ID minted by Put.
+type Store interface {
+	// Put stores e and returns a new opaque ID for it.
+	Put(e Entry) (id string, err error)
+	// Take looks up and removes the entry for id, so a given
+	// authorization flow can only be completed once. ok is false if id
+	// is unknown or its entry has expired.
+	Take(id string) (e Entry, ok bool)
+}
+
+// MemoryStore is the default Store, suitable for a single-instance
+// deployment; entries don't need to survive a restart since they're only
+// ever used for the few minutes between redirect and callback.
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]Entry
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]Entry)}
+}
+
+func (m *MemoryStore) Put(e Entry) (string, error) {
+	id, err := newID()
+	if err != nil {
+		return "", err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for existingID, entry := range m.entries {
+		if time.Since(entry.CreatedAt) > TTL {
+			delete(m.entries, existingID)
+		}
+	}
+	m.entries[id] = e
+
+	return id, nil
+}
+
+func (m *MemoryStore) Take(id string) (Entry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[id]
+	if !ok {
+		return Entry{}, false
+	}
+	delete(m.entries, id)
+
+	if time.Since(e.CreatedAt) > TTL {
+		return Entry{}, false
+	}
+	return e, true
+}
+
+func newID() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,77 @@
+package session
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"sync"
+)
+
+// MemoryStore is the default Store. It's suitable for local development and
+// single-instance deployments; state is lost on restart, which is fine for
+// this sample app but not for a real multi-instance deployment (use the
+// "redis" build tag for that).
+type MemoryStore struct {
+	mu       sync.RWMutex
+	sessions map[string]Session
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{sessions: make(map[string]Session)}
+}
+
+func (m *MemoryStore) Create(s Session) (string, error) {
+	id, err := newSessionID()
+	if err != nil {
+		return "", err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[id] = s
+
+	return id, nil
+}
+
+func (m *MemoryStore) Get(id string) (Session, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	s, ok := m.sessions[id]
+	return s, ok, nil
+}
+
+func (m *MemoryStore) Update(id string, s Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sessions[id] = s
+	return nil
+}
+
+func (m *MemoryStore) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.sessions, id)
+	return nil
+}
+
+func (m *MemoryStore) All() (map[string]Session, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	snapshot := make(map[string]Session, len(m.sessions))
+	for id, s := range m.sessions {
+		snapshot[id] = s
+	}
+	return snapshot, nil
+}
+
+func newSessionID() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,106 @@
+//go:build redis
+
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// keyPrefix namespaces session keys so this app can share a Redis instance
+// with other tenants.
+const keyPrefix = "rso_sample_app:session:"
+
+// RedisStore is a Store backed by Redis, for deployments that run more than
+// one instance of this app behind a load balancer. Build with
+// `go build -tags redis` to include it.
+type RedisStore struct {
+	client *redis.Client
+	ttl    int64 // seconds
+}
+
+// NewRedisStore returns a RedisStore using client, expiring each entry
+// ttlSeconds after it was last written by Create or Update, as a backstop
+// against leaked sessions. Get does not extend an entry's expiry.
+func NewRedisStore(client *redis.Client, ttlSeconds int64) *RedisStore {
+	return &RedisStore{client: client, ttl: ttlSeconds}
+}
+
+func (r *RedisStore) Create(s Session) (string, error) {
+	id, err := newSessionID()
+	if err != nil {
+		return "", err
+	}
+	if err := r.Update(id, s); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (r *RedisStore) Get(id string) (Session, bool, error) {
+	ctx := context.Background()
+
+	raw, err := r.client.Get(ctx, keyPrefix+id).Bytes()
+	if err == redis.Nil {
+		return Session{}, false, nil
+	}
+	if err != nil {
+		return Session{}, false, err
+	}
+
+	var s Session
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return Session{}, false, fmt.Errorf("unmarshal session %s: %w", id, err)
+	}
+	return s, true, nil
+}
+
+func (r *RedisStore) Update(id string, s Session) error {
+	raw, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("marshal session %s: %w", id, err)
+	}
+
+	ctx := context.Background()
+	return r.client.Set(ctx, keyPrefix+id, raw, time.Duration(r.ttl)*time.Second).Err()
+}
+
+func (r *RedisStore) Delete(id string) error {
+	ctx := context.Background()
+	return r.client.Del(ctx, keyPrefix+id).Err()
+}
+
+// All scans for every key under keyPrefix. It's only used by the background
+// refresh loop, which runs infrequently, so a SCAN is acceptable here even
+// though it's O(n) over the keyspace.
+func (r *RedisStore) All() (map[string]Session, error) {
+	ctx := context.Background()
+	out := make(map[string]Session)
+
+	iter := r.client.Scan(ctx, 0, keyPrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		raw, err := r.client.Get(ctx, key).Bytes()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var s Session
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, fmt.Errorf("unmarshal session %s: %w", key, err)
+		}
+		out[key[len(keyPrefix):]] = s
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
@@ -0,0 +1,46 @@
+// Package session defines the server-side session record created after a
+// successful identity provider token exchange, and the Store interface used
+// to persist it.
+// The access/refresh/id tokens never leave the server; callers only ever see
+// an opaque session ID carried in a cookie.
+package session
+
+import "time"
+
+// Session is what usecase.CompleteLogin persists once it has exchanged an
+// authorization code for tokens and fetched the user's Profile.
+type Session struct {
+	AccessToken  string
+	RefreshToken string
+	IDToken      string
+	ExpiresAt    time.Time
+	Puuid        string
+	GameName     string
+	TagLine      string
+}
+
+// Expired reports whether the access token has passed its expires_at.
+func (s Session) Expired() bool {
+	return time.Now().After(s.ExpiresAt)
+}
+
+// Store persists Sessions behind an opaque ID. Implementations must be safe
+// for concurrent use; the default is MemoryStore, with a Redis-backed
+// implementation available behind the "redis" build tag.
+type Store interface {
+	// Create persists s and returns a new opaque session ID for it.
+	Create(s Session) (id string, err error)
+	// Get looks up the session for id. ok is false if it doesn't exist
+	// (or has expired, for stores that expire entries natively).
+	Get(id string) (s Session, ok bool, err error)
+	// Update overwrites the session stored under id, e.g. after a
+	// refresh-token exchange rotates the tokens and expiry.
+	Update(id string, s Session) error
+	// Delete removes the session for id. It is not an error if id is
+	// already absent.
+	Delete(id string) error
+	// All returns a snapshot of every session currently held, keyed by
+	// ID, for the background refresh loop to scan for near-expiry
+	// sessions.
+	All() (map[string]Session, error)
+}
@@ -0,0 +1,68 @@
+package rso
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// idTokenClaims is the subset of RFC 7519 / OIDC claims this provider
+// checks on the id_token returned from the RSO token endpoint.
+type idTokenClaims struct {
+	Issuer   string `json:"iss"`
+	Subject  string `json:"sub"`
+	Audience string `json:"aud"`
+	Expiry   int64  `json:"exp"`
+	Nonce    string `json:"nonce"`
+}
+
+// parseIDToken decodes the JWT payload without verifying its signature.
+// This is acceptable here because the id_token comes back over the
+// TLS-secured token endpoint response rather than the redirect URI; a
+// provider that also accepts id_tokens from elsewhere should verify the
+// signature against RSO's JWKS as well.
+func parseIDToken(idToken string) (idTokenClaims, error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return idTokenClaims{}, fmt.Errorf("id_token: expected 3 dot-separated parts, got %d", len(parts))
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return idTokenClaims{}, fmt.Errorf("id_token: decode payload: %w", err)
+	}
+
+	var claims idTokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return idTokenClaims{}, fmt.Errorf("id_token: unmarshal claims: %w", err)
+	}
+
+	return claims, nil
+}
+
+// verifyIDToken parses idToken and checks that it was issued by the
+// provider's BaseURL for its ClientID, hasn't expired, and carries the
+// nonce sent in the authorize request.
+func (p *Provider) verifyIDToken(idToken, expectedNonce string) error {
+	claims, err := parseIDToken(idToken)
+	if err != nil {
+		return err
+	}
+
+	if claims.Issuer != p.cfg.BaseURL {
+		return fmt.Errorf("id_token: unexpected issuer %q", claims.Issuer)
+	}
+	if claims.Audience != p.cfg.ClientID {
+		return fmt.Errorf("id_token: unexpected audience %q", claims.Audience)
+	}
+	if time.Now().Unix() >= claims.Expiry {
+		return fmt.Errorf("id_token: expired")
+	}
+	if expectedNonce == "" || claims.Nonce != expectedNonce {
+		return fmt.Errorf("id_token: nonce mismatch")
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+// Package rso implements auth.IdentityProvider against Riot Sign-On,
+// Riot's OAuth2 + OIDC authorization server.
+package rso
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/RiotGames/developer-relations/sample_apps/rso_sample_apps/go/internal/auth"
+	"github.com/RiotGames/developer-relations/sample_apps/rso_sample_apps/go/riotapi"
+)
+
+// Config holds the per-client settings needed to talk to RSO.
+type Config struct {
+	BaseURL      string // e.g. https://auth.riotgames.com
+	ClientID     string
+	ClientSecret string
+	RedirectURI  string
+	ResponseType string
+	Scope        string
+}
+
+// Provider is the production auth.IdentityProvider backed by RSO.
+type Provider struct {
+	cfg        Config
+	httpClient *http.Client
+	riot       *riotapi.Client
+}
+
+// New returns a Provider. riot is used for UserInfo, which resolves the
+// account associated with the user's access token.
+func New(cfg Config, riot *riotapi.Client) *Provider {
+	return &Provider{cfg: cfg, httpClient: http.DefaultClient, riot: riot}
+}
+
+func (p *Provider) authorizeURLBase() string {
+	return fmt.Sprintf("%s/authorize", p.cfg.BaseURL)
+}
+
+func (p *Provider) tokenURL() string {
+	return fmt.Sprintf("%s/token", p.cfg.BaseURL)
+}
+
+// AuthorizeURL builds the RSO authorize URL for a single login attempt,
+// embedding state and pkce's challenge/method/nonce.
+func (p *Provider) AuthorizeURL(state string, pk auth.PKCE) string {
+	values := url.Values{}
+	values.Set("redirect_uri", p.cfg.RedirectURI)
+	values.Set("client_id", p.cfg.ClientID)
+	values.Set("response_type", p.cfg.ResponseType)
+	values.Set("scope", p.cfg.Scope)
+	values.Set("code_challenge", pk.Challenge)
+	values.Set("code_challenge_method", pk.Method)
+	values.Set("state", state)
+	values.Set("nonce", pk.Nonce)
+
+	return p.authorizeURLBase() + "?" + values.Encode()
+}
+
+// tokenResponse is the RSO token endpoint's response shape, shared by the
+// authorization_code and refresh_token grants.
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	Scope        string `json:"scope"`
+	IDToken      string `json:"id_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// Exchange trades an authorization code and the PKCE verifier that produced
+// its challenge for a Token, verifying the returned id_token's iss, aud,
+// exp, and nonce along the way.
+func (p *Provider) Exchange(ctx context.Context, code string, pk auth.PKCE) (*auth.Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURI)
+	form.Set("code_verifier", pk.Verifier)
+
+	tokenRes, err := p.postForm(ctx, form)
+	if err != nil {
+		return nil, fmt.Errorf("rso: exchange: %w", err)
+	}
+
+	if err := p.verifyIDToken(tokenRes.IDToken, pk.Nonce); err != nil {
+		return nil, fmt.Errorf("rso: exchange: %w", err)
+	}
+
+	return tokenFromResponse(tokenRes), nil
+}
+
+// Refresh trades a refresh token for a new Token.
+func (p *Provider) Refresh(ctx context.Context, refreshToken string) (*auth.Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", refreshToken)
+
+	tokenRes, err := p.postForm(ctx, form)
+	if err != nil {
+		return nil, fmt.Errorf("rso: refresh: %w", err)
+	}
+
+	return tokenFromResponse(tokenRes), nil
+}
+
+// UserInfo resolves the account bound to token.AccessToken via the Riot
+// account-v1 API.
+func (p *Provider) UserInfo(ctx context.Context, token *auth.Token) (*auth.Profile, error) {
+	account, err := p.riot.AccountByAccessToken(ctx, token.AccessToken)
+	if err != nil {
+		return nil, fmt.Errorf("rso: user info: %w", err)
+	}
+
+	return &auth.Profile{
+		Subject:  account.Puuid,
+		GameName: account.GameName,
+		TagLine:  account.TagLine,
+	}, nil
+}
+
+func (p *Provider) postForm(ctx context.Context, form url.Values) (tokenResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.tokenURL(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return tokenResponse{}, fmt.Errorf("build request: %w", err)
+	}
+	req.SetBasicAuth(p.cfg.ClientID, p.cfg.ClientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := p.httpClient.Do(req)
+	if err != nil {
+		return tokenResponse{}, fmt.Errorf("token request: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return tokenResponse{}, fmt.Errorf("read token response: %w", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return tokenResponse{}, fmt.Errorf("token request: %s: %s", res.Status, body)
+	}
+
+	var tokenRes tokenResponse
+	if err := json.Unmarshal(body, &tokenRes); err != nil {
+		return tokenResponse{}, fmt.Errorf("unmarshal token response: %w", err)
+	}
+
+	return tokenRes, nil
+}
+
+func tokenFromResponse(tokenRes tokenResponse) *auth.Token {
+	return &auth.Token{
+		AccessToken:  tokenRes.AccessToken,
+		RefreshToken: tokenRes.RefreshToken,
+		IDToken:      tokenRes.IDToken,
+		ExpiresAt:    time.Now().Add(time.Duration(tokenRes.ExpiresIn) * time.Second),
+	}
+}
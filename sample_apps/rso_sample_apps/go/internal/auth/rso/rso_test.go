@@ -0,0 +1,233 @@
+package rso
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/RiotGames/developer-relations/sample_apps/rso_sample_apps/go/internal/auth"
+	"github.com/RiotGames/developer-relations/sample_apps/rso_sample_apps/go/riotapi"
+)
+
+// encodeJWT builds an unsigned compact JWT carrying claims, good enough for
+// parseIDToken, which never checks the signature.
+func encodeJWT(t *testing.T, claims idTokenClaims) string {
+	t.Helper()
+
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("marshal claims: %v", err)
+	}
+
+	return header + "." + base64.RawURLEncoding.EncodeToString(payload) + ".sig"
+}
+
+func TestProviderExchange(t *testing.T) {
+	const nonce = "test-nonce"
+
+	tests := []struct {
+		name    string
+		claims  idTokenClaims
+		wantErr bool
+	}{
+		{
+			name: "valid id_token",
+			claims: idTokenClaims{
+				Issuer:   "placeholder", // filled in once the test server URL is known
+				Subject:  "puuid-123",
+				Audience: "test-client",
+				Expiry:   time.Now().Add(time.Hour).Unix(),
+				Nonce:    nonce,
+			},
+		},
+		{
+			name: "wrong audience",
+			claims: idTokenClaims{
+				Issuer:   "placeholder",
+				Subject:  "puuid-123",
+				Audience: "someone-else",
+				Expiry:   time.Now().Add(time.Hour).Unix(),
+				Nonce:    nonce,
+			},
+			wantErr: true,
+		},
+		{
+			name: "expired",
+			claims: idTokenClaims{
+				Issuer:   "placeholder",
+				Subject:  "puuid-123",
+				Audience: "test-client",
+				Expiry:   time.Now().Add(-time.Hour).Unix(),
+				Nonce:    nonce,
+			},
+			wantErr: true,
+		},
+		{
+			name: "nonce mismatch",
+			claims: idTokenClaims{
+				Issuer:   "placeholder",
+				Subject:  "puuid-123",
+				Audience: "test-client",
+				Expiry:   time.Now().Add(time.Hour).Unix(),
+				Nonce:    "not-the-nonce",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/token"):
+					claims := tt.claims
+					claims.Issuer = server.URL
+					w.Header().Set("Content-Type", "application/json")
+					json.NewEncoder(w).Encode(map[string]any{
+						"access_token":  "test-access-token",
+						"refresh_token": "test-refresh-token",
+						"id_token":      encodeJWT(t, claims),
+						"token_type":    "Bearer",
+						"expires_in":    3600,
+					})
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+
+			provider := New(Config{
+				BaseURL:      server.URL,
+				ClientID:     "test-client",
+				ClientSecret: "test-secret",
+				RedirectURI:  "https://app.example.com/callback",
+				ResponseType: "code",
+				Scope:        "openid",
+			}, riotapi.NewClient("unused", riotapi.NA1, riotapi.Americas))
+
+			token, err := provider.Exchange(context.Background(), "test-code", auth.PKCE{
+				Verifier: "test-verifier",
+				Nonce:    nonce,
+			})
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Exchange() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Exchange() error = %v, want nil", err)
+			}
+			if token.AccessToken != "test-access-token" {
+				t.Errorf("AccessToken = %q, want %q", token.AccessToken, "test-access-token")
+			}
+		})
+	}
+}
+
+func TestProviderUserInfo(t *testing.T) {
+	tests := []struct {
+		name        string
+		accessToken string
+		account     map[string]string
+		want        *auth.Profile
+		wantErr     bool
+	}{
+		{
+			name:        "maps account fields into a profile",
+			accessToken: "test-access-token",
+			account: map[string]string{
+				"puuid":    "puuid-123",
+				"gameName": "Faker",
+				"tagLine":  "KR1",
+			},
+			want: &auth.Profile{
+				Subject:  "puuid-123",
+				GameName: "Faker",
+				TagLine:  "KR1",
+			},
+		},
+		{
+			name:        "account endpoint error",
+			accessToken: "bad-access-token",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.HasSuffix(r.URL.Path, "/riot/account/v1/accounts/me") {
+					http.NotFound(w, r)
+					return
+				}
+				if r.Header.Get("Authorization") != "Bearer "+tt.accessToken {
+					http.Error(w, "unauthorized", http.StatusUnauthorized)
+					return
+				}
+				if tt.wantErr {
+					http.Error(w, "boom", http.StatusInternalServerError)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(tt.account)
+			}))
+			defer server.Close()
+
+			provider := New(Config{}, riotapi.NewClientWithBaseURL("unused", server.URL))
+
+			profile, err := provider.UserInfo(context.Background(), &auth.Token{AccessToken: tt.accessToken})
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("UserInfo() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UserInfo() error = %v, want nil", err)
+			}
+			if *profile != *tt.want {
+				t.Errorf("UserInfo() = %+v, want %+v", profile, tt.want)
+			}
+		})
+	}
+}
+
+func TestProviderAuthorizeURL(t *testing.T) {
+	provider := New(Config{
+		BaseURL:      "https://auth.example.com",
+		ClientID:     "test-client",
+		RedirectURI:  "https://app.example.com/callback",
+		ResponseType: "code",
+		Scope:        "openid",
+	}, nil)
+
+	authorizeURL := provider.AuthorizeURL("test-state", auth.PKCE{
+		Challenge: "test-challenge",
+		Method:    "S256",
+		Nonce:     "test-nonce",
+	})
+
+	for _, want := range []string{
+		"https://auth.example.com/authorize?",
+		"client_id=test-client",
+		"code_challenge=test-challenge",
+		"code_challenge_method=S256",
+		"state=test-state",
+		"nonce=test-nonce",
+	} {
+		if !strings.Contains(authorizeURL, want) {
+			t.Errorf("AuthorizeURL() = %q, want substring %q", authorizeURL, want)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Package usecase orchestrates the login flow against a single
+// auth.IdentityProvider, independent of how that flow is exposed over HTTP
+// and of which provider is configured.
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/RiotGames/developer-relations/sample_apps/rso_sample_apps/go/internal/auth"
+	"github.com/RiotGames/developer-relations/sample_apps/rso_sample_apps/go/internal/auth/repository/pkce"
+	"github.com/RiotGames/developer-relations/sample_apps/rso_sample_apps/go/internal/auth/repository/session"
+)
+
+// refreshBeforeExpiry is how far ahead of a session's expiry
+// RefreshDueSessions tries to rotate its access token.
+const refreshBeforeExpiry = 2 * time.Minute
+
+// UseCase orchestrates login, callback, and refresh against Provider.
+type UseCase struct {
+	Provider   auth.IdentityProvider
+	PKCEStore  pkce.Store
+	Sessions   session.Store
+	PKCEMethod string // "S256" or "plain"
+}
+
+// New returns a UseCase for provider, using pkceMethod ("S256" or "plain")
+// when building each login's code_challenge.
+func New(provider auth.IdentityProvider, pkceStore pkce.Store, sessions session.Store, pkceMethod string) *UseCase {
+	return &UseCase{
+		Provider:   provider,
+		PKCEStore:  pkceStore,
+		Sessions:   sessions,
+		PKCEMethod: pkceMethod,
+	}
+}
+
+// BeginLogin generates a fresh PKCE verifier/challenge, state, and nonce,
+// stashes them under a new PKCE session ID, and returns that ID alongside
+// the URL to send the user to.
+func (uc *UseCase) BeginLogin() (pkceSessionID, authorizeURL string, err error) {
+	verifier, err := randomString(32)
+	if err != nil {
+		return "", "", fmt.Errorf("usecase: begin login: %w", err)
+	}
+	state, err := randomString(16)
+	if err != nil {
+		return "", "", fmt.Errorf("usecase: begin login: %w", err)
+	}
+	nonce, err := randomString(16)
+	if err != nil {
+		return "", "", fmt.Errorf("usecase: begin login: %w", err)
+	}
+
+	challenge := verifier
+	if uc.PKCEMethod != "plain" {
+		challenge = codeChallengeS256(verifier)
+	}
+
+	p := auth.PKCE{Verifier: verifier, Challenge: challenge, Method: uc.PKCEMethod, Nonce: nonce}
+
+	pkceSessionID, err = uc.PKCEStore.Put(pkce.Entry{PKCE: p, State: state, CreatedAt: time.Now()})
+	if err != nil {
+		return "", "", fmt.Errorf("usecase: begin login: %w", err)
+	}
+
+	return pkceSessionID, uc.Provider.AuthorizeURL(state, p), nil
+}
+
+// CompleteLogin validates state, exchanges code for a Token, fetches the
+// user's Profile, and persists a new session. It returns the opaque session
+// ID for the session cookie.
+func (uc *UseCase) CompleteLogin(ctx context.Context, pkceSessionID, code, state string) (string, error) {
+	entry, ok := uc.PKCEStore.Take(pkceSessionID)
+	if !ok {
+		return "", errors.New("usecase: complete login: unknown or expired pkce session")
+	}
+	if state == "" || state != entry.State {
+		return "", errors.New("usecase: complete login: state mismatch")
+	}
+
+	token, err := uc.Provider.Exchange(ctx, code, entry.PKCE)
+	if err != nil {
+		return "", fmt.Errorf("usecase: complete login: exchange: %w", err)
+	}
+
+	profile, err := uc.Provider.UserInfo(ctx, token)
+	if err != nil {
+		return "", fmt.Errorf("usecase: complete login: user info: %w", err)
+	}
+
+	sessionID, err := uc.Sessions.Create(session.Session{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		IDToken:      token.IDToken,
+		ExpiresAt:    token.ExpiresAt,
+		Puuid:        profile.Subject,
+		GameName:     profile.GameName,
+		TagLine:      profile.TagLine,
+	})
+	if err != nil {
+		return "", fmt.Errorf("usecase: complete login: create session: %w", err)
+	}
+
+	return sessionID, nil
+}
+
+// RefreshDueSessions rotates the access token for every session within
+// refreshBeforeExpiry of expiring, continuing past individual failures and
+// returning them joined.
+func (uc *UseCase) RefreshDueSessions(ctx context.Context) error {
+	sessions, err := uc.Sessions.All()
+	if err != nil {
+		return fmt.Errorf("usecase: refresh due sessions: list: %w", err)
+	}
+
+	var errs []error
+	for id, sess := range sessions {
+		if time.Until(sess.ExpiresAt) > refreshBeforeExpiry {
+			continue
+		}
+
+		token, err := uc.Provider.Refresh(ctx, sess.RefreshToken)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("refresh session %s: %w", id, err))
+			continue
+		}
+
+		sess.AccessToken = token.AccessToken
+		if token.RefreshToken != "" {
+			sess.RefreshToken = token.RefreshToken
+		}
+		sess.ExpiresAt = token.ExpiresAt
+
+		if err := uc.Sessions.Update(id, sess); err != nil {
+			errs = append(errs, fmt.Errorf("update session %s: %w", id, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func randomString(byteLen int) (string, error) {
+	buf := make([]byte, byteLen)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func codeChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
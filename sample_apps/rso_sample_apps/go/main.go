@@ -1,21 +1,33 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"net/http"
-	"net/url"
 	"os"
 	"path/filepath"
-	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
+
+	"github.com/RiotGames/developer-relations/sample_apps/rso_sample_apps/go/internal/auth"
+	authdelivery "github.com/RiotGames/developer-relations/sample_apps/rso_sample_apps/go/internal/auth/delivery/http"
+	"github.com/RiotGames/developer-relations/sample_apps/rso_sample_apps/go/internal/auth/mockidp"
+	"github.com/RiotGames/developer-relations/sample_apps/rso_sample_apps/go/internal/auth/repository/pkce"
+	"github.com/RiotGames/developer-relations/sample_apps/rso_sample_apps/go/internal/auth/repository/session"
+	"github.com/RiotGames/developer-relations/sample_apps/rso_sample_apps/go/internal/auth/rso"
+	"github.com/RiotGames/developer-relations/sample_apps/rso_sample_apps/go/internal/auth/usecase"
+	"github.com/RiotGames/developer-relations/sample_apps/rso_sample_apps/go/riotapi"
+	"github.com/RiotGames/developer-relations/sample_apps/rso_sample_apps/go/web"
 )
 
 var CONFIG map[string]string
 
+// refreshInterval is how often the background session refresher checks for
+// access tokens nearing expiry.
+const refreshInterval = 1 * time.Minute
+
 func loadConfig() {
 	wd, err := os.Getwd()
 	if err != nil {
@@ -40,189 +52,97 @@ func loadConfig() {
 	CONFIG["SCOPE"] = os.Getenv("SCOPE")
 	CONFIG["RGAPI_TOKEN"] = os.Getenv("RGAPI_TOKEN")
 
-	CONFIG["TOKEN_URL"] = fmt.Sprintf("%s/token", CONFIG["RSO_BASE_URL"])
-	CONFIG["APP_CALLBACK_URL"] = fmt.Sprintf("%s%s", CONFIG["APP_BASE_URL"], CONFIG["APP_CALLBACK_PATH"])
-	CONFIG["AUTHORIZE_URL"] = fmt.Sprintf("%s/authorize", CONFIG["RSO_BASE_URL"])
-
-	CONFIG["SIGN_IN_URL"] = CONFIG["AUTHORIZE_URL"]
-	CONFIG["SIGN_IN_URL"] += fmt.Sprintf("?redirect_uri=%s", CONFIG["APP_CALLBACK_URL"])
-	CONFIG["SIGN_IN_URL"] += fmt.Sprintf("&client_id=%s", CONFIG["RSO_CLIENT_ID"])
-	CONFIG["SIGN_IN_URL"] += fmt.Sprintf("&response_type=%s", CONFIG["RESPONSE_TYPE"])
-	CONFIG["SIGN_IN_URL"] += fmt.Sprintf("&scope=%s", CONFIG["SCOPE"])
-}
-
-func login(w http.ResponseWriter, req *http.Request) {
-	fmt.Println("login")
-	html := fmt.Sprintf(
-		"<h1>login</h1><a href=\"%s\">Sign In --> %s</a>",
-		CONFIG["SIGN_IN_URL"], CONFIG["SIGN_IN_URL"])
-
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	w.Write([]byte(html))
-}
-
-func oauthCallback(w http.ResponseWriter, req *http.Request) {
-	type TokenURLResponse struct {
-		AccessToken  string `json:"access_token"`
-		RefreshToken string `json:"refresh_token"`
-		Scope        string `json:"scope"`
-		IDToken      string `json:"id_token"`
-		TokenType    string `json:"token_type"`
-		ExpiresIn    int    `json:"expires_in"`
+	// RIOT_API_REGION/RIOT_API_ROUTING select the riotapi.Client's platform
+	// and regional routing hosts. They default to the values this sample
+	// previously hardcoded (na1/americas).
+	CONFIG["RIOT_API_REGION"] = os.Getenv("RIOT_API_REGION")
+	if CONFIG["RIOT_API_REGION"] == "" {
+		CONFIG["RIOT_API_REGION"] = string(riotapi.NA1)
 	}
-
-	code := req.URL.Query().Get("code")
-
-	form := url.Values{}
-	form.Add("grant_type", "authorization_code")
-	form.Add("code", code)
-	form.Add("redirect_uri", CONFIG["APP_CALLBACK_URL"])
-
-	postReq, _ := http.NewRequest(
-		"POST",
-		CONFIG["TOKEN_URL"],
-		strings.NewReader(form.Encode()),
-	)
-	postReq.SetBasicAuth(CONFIG["RSO_CLIENT_ID"], CONFIG["RSO_CLIENT_SECRET"])
-	postReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	postRes, _ := http.DefaultClient.Do(postReq)
-	defer postRes.Body.Close()
-
-	body, _ := ioutil.ReadAll(postRes.Body)
-
-	var tokenURLResponse TokenURLResponse
-	json.Unmarshal(body, &tokenURLResponse)
-
-	queryString := fmt.Sprintf("access_token=%s", tokenURLResponse.AccessToken)
-
-	html := fmt.Sprintf(
-		"<script>window.location.href=\"/show-data/?%s\";</script>",
-		queryString)
-	w.Write([]byte(html))
-}
-
-func showData(w http.ResponseWriter, req *http.Request) {
-	accessToken := req.URL.Query().Get("access_token")
-
-	accountData := getAccountData(accessToken)
-	accountHTML := fmt.Sprintf(
-		"<h2>account data queried using RSO Access Token:</h2><p>%v</p>",
-		accountData)
-
-	championRotationData := getChampionRotationData(CONFIG["RGAPI_TOKEN"])
-	championRotationHTML := fmt.Sprintf(
-		"<h2>champion rotation data queried using RGAPI token</h2><p>%v</p>",
-		championRotationData)
-
-	html := fmt.Sprintf("%s %s", accountHTML, championRotationHTML)
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	w.Write([]byte(html))
-}
-
-func getAccountData(accessToken string) string {
-	type AccountData struct {
-		Puuid    string `html:"l=Puuid,e=span,c=puuid"`
-		GameName string `html:"l=GameName,e=span,c=gamename"`
-		TagLine  string `html:"l=TagLine,e=span,c=tagline"`
+	CONFIG["RIOT_API_ROUTING"] = os.Getenv("RIOT_API_ROUTING")
+	if CONFIG["RIOT_API_ROUTING"] == "" {
+		CONFIG["RIOT_API_ROUTING"] = string(riotapi.Americas)
 	}
 
-	req, _ := http.NewRequest(
-		"GET",
-		"https://americas.api.riotgames.com/riot/account/v1/accounts/me",
-		nil,
-	)
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", accessToken))
-	res, _ := http.DefaultClient.Do(req)
-	defer res.Body.Close()
-
-	body, _ := ioutil.ReadAll(res.Body)
-
-	var accountData AccountData
-	json.Unmarshal(body, &accountData)
+	// PKCE_METHOD selects the RFC 7636 code_challenge_method. S256 is the
+	// only method that should be used against real RSO; "plain" exists so
+	// this sample can be exercised against test IdPs that don't support
+	// S256 yet.
+	CONFIG["PKCE_METHOD"] = os.Getenv("PKCE_METHOD")
+	if CONFIG["PKCE_METHOD"] == "" {
+		CONFIG["PKCE_METHOD"] = "S256"
+	}
 
-	html, _ := structToHTML(map[string]string{
-		"puuid":    fmt.Sprint(accountData.Puuid),
-		"gameName": fmt.Sprint(accountData.GameName),
-		"tagLine":  fmt.Sprint(accountData.TagLine),
-	})
+	// AUTH_PROVIDER selects the auth.IdentityProvider implementation:
+	// "rso" talks to real Riot Sign-On; "mockidp" reads canned responses
+	// from fixture files so login and the account half of /show-data/ work
+	// without real RSO credentials. The champion rotation half of
+	// /show-data/ always calls the real Riot API, so a real RGAPI_TOKEN is
+	// still required regardless of AUTH_PROVIDER.
+	CONFIG["AUTH_PROVIDER"] = os.Getenv("AUTH_PROVIDER")
+	if CONFIG["AUTH_PROVIDER"] == "" {
+		CONFIG["AUTH_PROVIDER"] = "rso"
+	}
+	CONFIG["MOCKIDP_FIXTURES_DIR"] = os.Getenv("MOCKIDP_FIXTURES_DIR")
 
-	return html
+	CONFIG["APP_CALLBACK_URL"] = fmt.Sprintf("%s%s", CONFIG["APP_BASE_URL"], CONFIG["APP_CALLBACK_PATH"])
 }
 
-func getChampionRotationData(token string) string {
-	type ChampionRotationData struct {
-		FreeChampionIds              []int
-		FreeChampionIdsForNewPlayers []int
-		MaxNewPlayerLevel            int
-	}
+func main() {
+	loadConfig()
 
-	req, _ := http.NewRequest(
-		"GET",
-		"https://na1.api.riotgames.com/lol/platform/v3/champion-rotations",
-		nil,
+	riotapiClient := riotapi.NewClient(
+		CONFIG["RGAPI_TOKEN"],
+		riotapi.Region(CONFIG["RIOT_API_REGION"]),
+		riotapi.RegionalRouting(CONFIG["RIOT_API_ROUTING"]),
 	)
-	req.Header.Add("X-Riot-Token", token)
-	res, _ := http.DefaultClient.Do(req)
-	defer res.Body.Close()
 
-	body, _ := ioutil.ReadAll(res.Body)
+	var provider auth.IdentityProvider
+	switch CONFIG["AUTH_PROVIDER"] {
+	case "mockidp":
+		provider = mockidp.New(CONFIG["MOCKIDP_FIXTURES_DIR"])
+	case "rso":
+		provider = rso.New(rso.Config{
+			BaseURL:      CONFIG["RSO_BASE_URL"],
+			ClientID:     CONFIG["RSO_CLIENT_ID"],
+			ClientSecret: CONFIG["RSO_CLIENT_SECRET"],
+			RedirectURI:  CONFIG["APP_CALLBACK_URL"],
+			ResponseType: CONFIG["RESPONSE_TYPE"],
+			Scope:        CONFIG["SCOPE"],
+		}, riotapiClient)
+	default:
+		log.Fatalf("unknown AUTH_PROVIDER %q", CONFIG["AUTH_PROVIDER"])
+	}
 
-	var championRotationData ChampionRotationData
-	json.Unmarshal(body, &championRotationData)
+	sessionStore := session.NewMemoryStore()
+	pkceStore := pkce.NewMemoryStore()
+	uc := usecase.New(provider, pkceStore, sessionStore, CONFIG["PKCE_METHOD"])
 
-	html, _ := structToHTML(map[string]string{
-		"freeChampionIds":              fmt.Sprint(championRotationData.FreeChampionIds),
-		"freeChampionIdsForNewPlayers": fmt.Sprint(championRotationData.FreeChampionIdsForNewPlayers),
-		"maxNewPlayerLevel":            fmt.Sprint(championRotationData.MaxNewPlayerLevel),
-	})
+	renderer, err := web.NewRenderer()
+	if err != nil {
+		log.Fatalf("failed to load templates: %v", err)
+	}
 
-	return html
-}
+	handler := authdelivery.New(uc, sessionStore, riotapiClient, renderer)
 
-func structToHTML(data map[string]string) (string, error) {
-	style := `
-	<style type="text/css">
-.tg  {border-collapse:collapse;border-spacing:0;}
-.tg td{border-color:black;border-style:solid;border-width:1px;font-family:Arial, sans-serif;font-size:14px;
-overflow:hidden;padding:10px 5px;word-break:normal;}
-.tg th{border-color:black;border-style:solid;border-width:1px;font-family:Arial, sans-serif;font-size:14px;
-font-weight:normal;overflow:hidden;padding:10px 5px;word-break:normal;}
-.tg .tg-0lax{text-align:left;vertical-align:top}
-</style>`
-	html := `
-	<table class="tg">
-	<thead>
-	<tr>
-		<th class="tg-0lax">key</th>
-		<th class="tg-0lax">value</th>
-	</tr>
-	</thead>
-
-	<tbody>`
-
-	for key, value := range data {
-		html += fmt.Sprintf(`
-		<tr>
-			<td class="tg-0lax">%s</td>
-			<td class="tg-0lax">%s<br></td>
-		</tr>`, key, value)
-	}
+	go startSessionRefresher(uc)
 
-	html += `
-    </tbody>
-    </table>
-        `
+	http.HandleFunc("/", handler.Login)
+	http.HandleFunc(CONFIG["APP_CALLBACK_PATH"], handler.Callback)
+	http.HandleFunc("/show-data/", handler.ShowData)
 
-	return style + html, nil
+	http.ListenAndServe(":3000", nil)
 }
 
-func main() {
-	loadConfig()
-
-	http.HandleFunc("/", login)
-	http.HandleFunc(CONFIG["APP_CALLBACK_PATH"], oauthCallback)
-	http.HandleFunc("/show-data/", showData)
+// startSessionRefresher runs until the process exits, periodically rotating
+// any session whose access token is nearing expiry.
+func startSessionRefresher(uc *usecase.UseCase) {
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
 
-	http.ListenAndServe(":3000", nil)
+	for range ticker.C {
+		if err := uc.RefreshDueSessions(context.Background()); err != nil {
+			log.Printf("session refresher: %v", err)
+		}
+	}
 }
@@ -0,0 +1,56 @@
+package riotapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// Account is the riot/account-v1 response shape. The html tags drive
+// web.renderStruct for the profile page.
+type Account struct {
+	Puuid    string `json:"puuid" html:"l=Puuid,e=span,c=puuid"`
+	GameName string `json:"gameName" html:"l=GameName,e=span,c=gamename"`
+	TagLine  string `json:"tagLine" html:"l=TagLine,e=span,c=tagline"`
+}
+
+// AccountByAccessToken returns the account bound to accessToken, an RSO
+// user access token (the caller's, obtained via the authorization code
+// flow).
+func (c *Client) AccountByAccessToken(ctx context.Context, accessToken string) (*Account, error) {
+	url := c.regionalHost() + "/riot/account/v1/accounts/me"
+
+	body, err := c.get(ctx, "AccountByAccessToken", url, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var account Account
+	if err := json.Unmarshal(body, &account); err != nil {
+		return nil, fmt.Errorf("riotapi: AccountByAccessToken: unmarshal: %w", err)
+	}
+
+	return &account, nil
+}
+
+// AccountByRiotID looks up an account by its Riot ID (gameName#tagLine),
+// authenticating with the client's API key.
+func (c *Client) AccountByRiotID(ctx context.Context, gameName, tagLine string) (*Account, error) {
+	reqURL := fmt.Sprintf(
+		"%s/riot/account/v1/accounts/by-riot-id/%s/%s",
+		c.regionalHost(), url.PathEscape(gameName), url.PathEscape(tagLine),
+	)
+
+	body, err := c.get(ctx, "AccountByRiotID", reqURL, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var account Account
+	if err := json.Unmarshal(body, &account); err != nil {
+		return nil, fmt.Errorf("riotapi: AccountByRiotID: unmarshal: %w", err)
+	}
+
+	return &account, nil
+}
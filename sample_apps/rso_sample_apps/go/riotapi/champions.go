@@ -0,0 +1,33 @@
+package riotapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ChampionRotation is the lol/platform/v3/champion-rotations response
+// shape. The html tags drive web.renderStruct for the profile page.
+type ChampionRotation struct {
+	FreeChampionIds              []int `json:"freeChampionIds" html:"l=FreeChampionIds,e=span,c=freechampionids"`
+	FreeChampionIdsForNewPlayers []int `json:"freeChampionIdsForNewPlayers" html:"l=FreeChampionIdsForNewPlayers,e=span,c=freechampionidsfornewplayers"`
+	MaxNewPlayerLevel            int   `json:"maxNewPlayerLevel" html:"l=MaxNewPlayerLevel,e=span,c=maxnewplayerlevel"`
+}
+
+// ChampionRotations returns the current free-to-play champion rotation for
+// the client's platform region.
+func (c *Client) ChampionRotations(ctx context.Context) (*ChampionRotation, error) {
+	url := c.platformHost() + "/lol/platform/v3/champion-rotations"
+
+	body, err := c.get(ctx, "ChampionRotations", url, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var rotation ChampionRotation
+	if err := json.Unmarshal(body, &rotation); err != nil {
+		return nil, fmt.Errorf("riotapi: ChampionRotations: unmarshal: %w", err)
+	}
+
+	return &rotation, nil
+}
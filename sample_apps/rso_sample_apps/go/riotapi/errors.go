@@ -0,0 +1,64 @@
+package riotapi
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Sentinel errors callers can match with errors.Is. APIError wraps whichever
+// of these applies alongside the raw status and response body.
+var (
+	ErrForbidden   = errors.New("riotapi: forbidden")
+	ErrNotFound    = errors.New("riotapi: not found")
+	ErrRateLimited = errors.New("riotapi: rate limited")
+)
+
+// APIError is returned for any non-2xx response. Use errors.Is against
+// ErrForbidden, ErrNotFound, or ErrRateLimited to branch on the failure kind;
+// other status codes are returned as a plain APIError.
+type APIError struct {
+	Method     string
+	StatusCode int
+	Body       string
+	wrapped    error
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("riotapi: %s: %s (%d): %s", e.Method, http.StatusText(e.StatusCode), e.StatusCode, e.Body)
+}
+
+func (e *APIError) Unwrap() error {
+	return e.wrapped
+}
+
+func newAPIError(method string, statusCode int, body string) error {
+	apiErr := &APIError{Method: method, StatusCode: statusCode, Body: body}
+
+	switch statusCode {
+	case http.StatusForbidden:
+		apiErr.wrapped = ErrForbidden
+	case http.StatusNotFound:
+		apiErr.wrapped = ErrNotFound
+	case http.StatusTooManyRequests:
+		apiErr.wrapped = ErrRateLimited
+	}
+
+	return apiErr
+}
+
+// readBody returns the response body on success, or an *APIError (wrapping
+// one of the sentinel errors where applicable) on a non-2xx status.
+func readBody(res *http.Response, method string) ([]byte, error) {
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("riotapi: %s: read response: %w", method, err)
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, newAPIError(method, res.StatusCode, string(body))
+	}
+
+	return body, nil
+}
@@ -0,0 +1,93 @@
+package riotapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// MatchListOptions are the optional query parameters accepted by
+// lol/match/v5/matches/by-puuid/{puuid}/ids.
+type MatchListOptions struct {
+	Start int
+	Count int // 0 means "let the API use its default"
+}
+
+// MatchIDsByPUUID returns the IDs of puuid's recent matches.
+func (c *Client) MatchIDsByPUUID(ctx context.Context, puuid string, opts MatchListOptions) ([]string, error) {
+	reqURL := fmt.Sprintf("%s/lol/match/v5/matches/by-puuid/%s/ids", c.regionalHost(), url.PathEscape(puuid))
+
+	query := url.Values{}
+	if opts.Start != 0 {
+		query.Set("start", strconv.Itoa(opts.Start))
+	}
+	if opts.Count != 0 {
+		query.Set("count", strconv.Itoa(opts.Count))
+	}
+	if encoded := query.Encode(); encoded != "" {
+		reqURL += "?" + encoded
+	}
+
+	body, err := c.get(ctx, "MatchIDsByPUUID", reqURL, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	if err := json.Unmarshal(body, &ids); err != nil {
+		return nil, fmt.Errorf("riotapi: MatchIDsByPUUID: unmarshal: %w", err)
+	}
+
+	return ids, nil
+}
+
+// Match is a lol/match/v5/matches/{id} response. Metadata and Info are left
+// as raw JSON since the match payload is large and evolves per patch;
+// callers decode the fields they need from them.
+type Match struct {
+	Metadata json.RawMessage `json:"metadata"`
+	Info     json.RawMessage `json:"info"`
+}
+
+// MatchByID returns the full match payload for id.
+func (c *Client) MatchByID(ctx context.Context, id string) (*Match, error) {
+	reqURL := fmt.Sprintf("%s/lol/match/v5/matches/%s", c.regionalHost(), url.PathEscape(id))
+
+	body, err := c.get(ctx, "MatchByID", reqURL, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var match Match
+	if err := json.Unmarshal(body, &match); err != nil {
+		return nil, fmt.Errorf("riotapi: MatchByID: unmarshal: %w", err)
+	}
+
+	return &match, nil
+}
+
+// MatchTimeline is a lol/match/v5/matches/{id}/timeline response, likewise
+// left as raw JSON for the same reason as Match.
+type MatchTimeline struct {
+	Metadata json.RawMessage `json:"metadata"`
+	Info     json.RawMessage `json:"info"`
+}
+
+// MatchTimeline returns the frame-by-frame timeline for match id.
+func (c *Client) MatchTimeline(ctx context.Context, id string) (*MatchTimeline, error) {
+	reqURL := fmt.Sprintf("%s/lol/match/v5/matches/%s/timeline", c.regionalHost(), url.PathEscape(id))
+
+	body, err := c.get(ctx, "MatchTimeline", reqURL, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var timeline MatchTimeline
+	if err := json.Unmarshal(body, &timeline); err != nil {
+		return nil, fmt.Errorf("riotapi: MatchTimeline: unmarshal: %w", err)
+	}
+
+	return &timeline, nil
+}
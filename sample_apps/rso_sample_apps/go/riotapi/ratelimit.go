@@ -0,0 +1,165 @@
+package riotapi
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rateLimitKey identifies one of the Riot API's independent rate-limit
+// buckets: a method (endpoint) within a region/routing value.
+type rateLimitKey struct {
+	method string
+	region string
+}
+
+// bucket tracks the most recently observed limit/count for one window size
+// (e.g. "10:1" meaning 10 requests per 1 second) reported by the API. kind
+// distinguishes an app-wide bucket from a method bucket of the same
+// windowSeconds, so the two are never confused for each other when carrying
+// windowStart forward across updates.
+type bucket struct {
+	kind          string
+	windowSeconds int
+	limit         int
+	count         int
+	windowStart   time.Time
+}
+
+// rateLimiter throttles requests per (method, region) using the
+// X-App-Rate-Limit, X-App-Rate-Limit-Count, X-Method-Rate-Limit, and
+// X-Method-Rate-Limit-Count headers the Riot API returns on every response,
+// so this client backs off before hitting a 429 rather than only reacting
+// after one.
+type rateLimiter struct {
+	mu      sync.Mutex
+	buckets map[rateLimitKey][]bucket
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{buckets: make(map[rateLimitKey][]bucket)}
+}
+
+// Wait blocks until key's buckets (if any are known from a prior response)
+// have headroom, or ctx is done.
+func (r *rateLimiter) Wait(ctx context.Context, key rateLimitKey) error {
+	for {
+		wait := r.nextAvailable(key)
+		if wait <= 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (r *rateLimiter) nextAvailable(key rateLimitKey) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var longest time.Duration
+	for _, b := range r.buckets[key] {
+		if b.count < b.limit {
+			continue
+		}
+		remaining := time.Duration(b.windowSeconds)*time.Second - time.Since(b.windowStart)
+		if remaining > longest {
+			longest = remaining
+		}
+	}
+	return longest
+}
+
+// Update records the rate-limit headers from a response for key, so the
+// next Wait call for the same bucket knows how much headroom is left.
+func (r *rateLimiter) Update(key rateLimitKey, header http.Header) {
+	limits := parseLimitHeader(header.Get("X-App-Rate-Limit"))
+	counts := parseLimitHeader(header.Get("X-App-Rate-Limit-Count"))
+	methodLimits := parseLimitHeader(header.Get("X-Method-Rate-Limit"))
+	methodCounts := parseLimitHeader(header.Get("X-Method-Rate-Limit-Count"))
+
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing := make(map[string]bucket, len(r.buckets[key]))
+	for _, b := range r.buckets[key] {
+		existing[b.kind+":"+strconv.Itoa(b.windowSeconds)] = b
+	}
+
+	// App-wide buckets are shared across methods within a region; method
+	// buckets are specific to this endpoint. Track both so either one
+	// tripping throttles the next Wait.
+	buckets := make([]bucket, 0, len(limits)+len(methodLimits))
+	buckets = append(buckets, mergeBuckets("app", limits, counts, now, existing)...)
+	buckets = append(buckets, mergeBuckets("method", methodLimits, methodCounts, now, existing)...)
+
+	if len(buckets) == 0 {
+		return
+	}
+
+	r.buckets[key] = buckets
+}
+
+// mergeBuckets builds this response's buckets for one header pair (app or
+// method). X-*-Rate-Limit-Count reflects usage in a window that began at
+// the first request counted in it, not at this response, so windowStart is
+// carried forward from the matching existing bucket as long as the count
+// hasn't reset and that window hasn't already elapsed; otherwise a new
+// window has started and windowStart resets to now.
+func mergeBuckets(kind string, limits, counts map[int]int, now time.Time, existing map[string]bucket) []bucket {
+	out := make([]bucket, 0, len(limits))
+	for windowSeconds, limit := range limits {
+		count := counts[windowSeconds]
+		windowStart := now
+
+		if prev, ok := existing[kind+":"+strconv.Itoa(windowSeconds)]; ok &&
+			count >= prev.count &&
+			now.Sub(prev.windowStart) < time.Duration(windowSeconds)*time.Second {
+			windowStart = prev.windowStart
+		}
+
+		out = append(out, bucket{
+			kind:          kind,
+			windowSeconds: windowSeconds,
+			limit:         limit,
+			count:         count,
+			windowStart:   windowStart,
+		})
+	}
+	return out
+}
+
+// parseLimitHeader parses a header like "20:1,100:120" into
+// {1: 20, 120: 100}, i.e. windowSeconds -> limit-or-count.
+func parseLimitHeader(value string) map[int]int {
+	out := make(map[int]int)
+	if value == "" {
+		return out
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.SplitN(pair, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		n, err1 := strconv.Atoi(strings.TrimSpace(parts[0]))
+		windowSeconds, err2 := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		out[windowSeconds] = n
+	}
+
+	return out
+}
@@ -0,0 +1,45 @@
+package riotapi
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// checkRetry retries on connection errors and on the status codes the Riot
+// API uses to signal a transient condition: 429 (rate limited) and 503
+// (service unavailable). Anything else falls through to
+// retryablehttp.DefaultRetryPolicy, which also retries other 5xx responses
+// (except 501).
+func checkRetry(ctx context.Context, res *http.Response, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+	if err != nil {
+		return retryablehttp.DefaultRetryPolicy(ctx, res, err)
+	}
+	if res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusServiceUnavailable {
+		return true, nil
+	}
+	return retryablehttp.DefaultRetryPolicy(ctx, res, err)
+}
+
+// retryAfterAwareBackoff honors the Retry-After header the Riot API sends
+// with 429/503 responses, falling back to exponential backoff otherwise.
+func retryAfterAwareBackoff(minWait, maxWait time.Duration, attemptNum int, res *http.Response) time.Duration {
+	if res != nil {
+		if retryAfter := res.Header.Get("Retry-After"); retryAfter != "" {
+			if seconds, err := strconv.Atoi(retryAfter); err == nil {
+				wait := time.Duration(seconds) * time.Second
+				if wait > maxWait {
+					return maxWait
+				}
+				return wait
+			}
+		}
+	}
+	return retryablehttp.DefaultBackoff(minWait, maxWait, attemptNum, res)
+}
@@ -0,0 +1,132 @@
+// Package riotapi is a small typed client for the Riot Games API. It
+// centralizes regional routing, rate-limit awareness, and retry behavior so
+// callers don't have to hand-roll *http.Request calls per endpoint. Its
+// game-specific methods call League of Legends (lol/...) endpoints; other
+// games' endpoints (TFT, VAL) are not covered by this client.
+package riotapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// Region is a Riot platform, e.g. "na1", "euw1", "kr".
+type Region string
+
+// Platforms in common use by this sample. The API accepts others; this list
+// isn't exhaustive.
+const (
+	NA1   Region = "na1"
+	EUW1  Region = "euw1"
+	EUNE1 Region = "eun1"
+	KR    Region = "kr"
+	BR1   Region = "br1"
+)
+
+// RegionalRouting is one of the continental routing values used by the
+// account-v1 and match-v5 endpoints.
+type RegionalRouting string
+
+const (
+	Americas RegionalRouting = "americas"
+	Europe   RegionalRouting = "europe"
+	Asia     RegionalRouting = "asia"
+	SEA      RegionalRouting = "sea"
+)
+
+// Client is a typed Riot API client for a single (region, routing) pair and
+// API key. Construct one per credential; it's safe for concurrent use.
+type Client struct {
+	httpClient *retryablehttp.Client
+	apiKey     string
+	region     Region
+	routing    RegionalRouting
+	baseURL    string
+	limiter    *rateLimiter
+}
+
+// NewClient returns a Client that authenticates with apiKey and targets
+// region for platform endpoints (e.g. champion rotations) and routing for
+// regional endpoints (e.g. match history).
+func NewClient(apiKey string, region Region, routing RegionalRouting) *Client {
+	return newClient(apiKey, region, routing, "")
+}
+
+// NewClientWithBaseURL returns a Client identical to NewClient but with
+// platform and regional hosts both pinned to baseURL instead of
+// *.api.riotgames.com, so tests can point it at an httptest.Server.
+func NewClientWithBaseURL(apiKey string, baseURL string) *Client {
+	return newClient(apiKey, "", "", baseURL)
+}
+
+func newClient(apiKey string, region Region, routing RegionalRouting, baseURL string) *Client {
+	httpClient := retryablehttp.NewClient()
+	httpClient.Logger = nil
+	httpClient.RetryMax = 3
+	httpClient.RetryWaitMin = 500 * time.Millisecond
+	httpClient.RetryWaitMax = 8 * time.Second
+	httpClient.CheckRetry = checkRetry
+	httpClient.Backoff = retryAfterAwareBackoff
+	// Hand the last response back once retries are exhausted, so readBody
+	// can turn a final 429/503 into an *APIError.
+	httpClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
+
+	return &Client{
+		httpClient: httpClient,
+		apiKey:     apiKey,
+		region:     region,
+		routing:    routing,
+		baseURL:    baseURL,
+		limiter:    newRateLimiter(),
+	}
+}
+
+func (c *Client) platformHost() string {
+	if c.baseURL != "" {
+		return c.baseURL
+	}
+	return fmt.Sprintf("https://%s.api.riotgames.com", c.region)
+}
+
+func (c *Client) regionalHost() string {
+	if c.baseURL != "" {
+		return c.baseURL
+	}
+	return fmt.Sprintf("https://%s.api.riotgames.com", c.routing)
+}
+
+// get issues an authenticated GET to path (already host-qualified), waiting
+// on the rate limiter for method/region first and recording the response's
+// rate-limit headers afterwards. bearerToken, if non-empty, is sent as a
+// Bearer token instead of the client's X-Riot-Token API key (used for
+// endpoints scoped to an RSO-authenticated user).
+func (c *Client) get(ctx context.Context, method, url, bearerToken string) ([]byte, error) {
+	limiterKey := rateLimitKey{method: method, region: string(c.region) + string(c.routing)}
+	if err := c.limiter.Wait(ctx, limiterKey); err != nil {
+		return nil, err
+	}
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("riotapi: build request: %w", err)
+	}
+	if bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+bearerToken)
+	} else {
+		req.Header.Set("X-Riot-Token", c.apiKey)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("riotapi: %s: %w", method, err)
+	}
+	defer res.Body.Close()
+
+	c.limiter.Update(limiterKey, res.Header)
+
+	return readBody(res, method)
+}
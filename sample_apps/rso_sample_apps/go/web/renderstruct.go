@@ -0,0 +1,75 @@
+package web
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"reflect"
+	"strings"
+)
+
+// renderStruct reflects over v's fields, reading each one's `html` struct
+// tag (e.g. `html:"l=Puuid,e=span,c=puuid"`) to produce one element per
+// tagged field: <ELEM class="CLASS">LABEL: VALUE</ELEM>. Fields without an
+// `html` tag are skipped. It's registered as the "renderStruct" template
+// func and used from profile.gohtml.
+func renderStruct(v any) template.HTML {
+	val := reflect.ValueOf(v)
+	if val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return ""
+	}
+
+	var b strings.Builder
+	t := val.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, ok := field.Tag.Lookup("html")
+		if !ok {
+			continue
+		}
+
+		label, elem, class := parseHTMLTag(tag)
+		if elem == "" {
+			elem = "span"
+		}
+		if label == "" {
+			label = field.Name
+		}
+
+		fmt.Fprintf(&b, `<%s class="%s">%s: %s</%s>`,
+			elem,
+			html.EscapeString(class),
+			html.EscapeString(label),
+			html.EscapeString(fmt.Sprint(val.Field(i).Interface())),
+			elem)
+	}
+
+	// The class, label, and field value above were each run through
+	// html.EscapeString; the element name is written unescaped and comes
+	// only from the struct tag in source code, not from runtime data. That
+	// is what makes it safe to mark the assembled result as trusted HTML.
+	return template.HTML(b.String())
+}
+
+// parseHTMLTag parses a struct tag of the form "l=Label,e=span,c=class"
+// into its label, element, and class components.
+func parseHTMLTag(tag string) (label, elem, class string) {
+	for _, part := range strings.Split(tag, ",") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "l":
+			label = kv[1]
+		case "e":
+			elem = kv[1]
+		case "c":
+			class = kv[1]
+		}
+	}
+	return label, elem, class
+}